@@ -2,19 +2,22 @@ package ctrl
 
 import (
 	"context"
-	entSql "entgo.io/ent/dialect/sql"
 	"fmt"
 	"github.com/google/go-github/v53/github"
 	"github.com/kataras/golog"
 	"github.com/pkg/errors"
-	"github.com/zema1/watchvuln/ent"
-	"github.com/zema1/watchvuln/ent/migrate"
-	"github.com/zema1/watchvuln/ent/vulninformation"
+	"github.com/zema1/watchvuln/cvelist"
+	"github.com/zema1/watchvuln/ghclient"
 	"github.com/zema1/watchvuln/grab"
+	"github.com/zema1/watchvuln/httpapi"
+	"github.com/zema1/watchvuln/issues"
+	"github.com/zema1/watchvuln/metrics"
+	"github.com/zema1/watchvuln/nuclei"
 	"github.com/zema1/watchvuln/push"
+	"github.com/zema1/watchvuln/store"
+	"github.com/zema1/watchvuln/suppress"
 	"golang.org/x/sync/errgroup"
 	"net/http"
-	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -27,27 +30,32 @@ type WatchVulnApp struct {
 	textPusher push.TextPusher
 	rawPusher  push.RawPusher
 
-	log          *golog.Logger
-	db           *ent.Client
-	githubClient *github.Client
-	grabbers     []grab.Grabber
-	prs          []*github.PullRequest
+	log            *golog.Logger
+	db             store.Store
+	githubClient   *github.Client
+	issuesClient   issues.Client
+	suppressEngine *suppress.Engine
+	cveRepo        *cvelist.Repo
+	nucleiIndexer  *nuclei.Indexer
+	grabbers       []grab.Grabber
+
+	// tickMu serializes tick, since it's triggered both by the ticker loop
+	// and by the HTTP API's POST /tick; without it two overlapping passes
+	// could double-process and double-push the same vulns.
+	tickMu sync.Mutex
 }
 
 func NewApp(config *WatchVulnAppConfig, textPusher push.TextPusher, rawPusher push.RawPusher) (*WatchVulnApp, error) {
-	drv, err := entSql.Open("sqlite3", "file:vuln_v2.sqlite3?cache=shared&_pragma=foreign_keys(1)")
-	if err != nil {
-		return nil, errors.Wrap(err, "failed opening connection to sqlite")
-	}
-	db := drv.DB()
-	db.SetMaxOpenConns(1)
-	dbClient := ent.NewClient(ent.Driver(drv))
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
-	if err := dbClient.Schema.Create(ctx, migrate.WithDropIndex(true), migrate.WithDropColumn(true)); err != nil {
-		return nil, errors.Wrap(err, "failed creating schema resources")
+
+	db, err := newStore(ctx, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed opening store")
 	}
 
+	githubClient := ghclient.New(config.GithubToken)
+
 	var grabs []grab.Grabber
 	for _, part := range config.Sources {
 		part = strings.ToLower(strings.TrimSpace(part))
@@ -60,29 +68,97 @@ func NewApp(config *WatchVulnAppConfig, textPusher push.TextPusher, rawPusher pu
 			grabs = append(grabs, grab.NewOSCSCrawler())
 		case "seebug":
 			grabs = append(grabs, grab.NewSeebugCrawler())
+		case "ghsa":
+			grabs = append(grabs, grab.NewGHSACrawler(ghclient.NewGraphQL(config.GithubToken)))
 		default:
 			return nil, fmt.Errorf("invalid grab source %s", part)
 		}
 	}
 
-	tr := http.DefaultTransport.(*http.Transport).Clone()
-	tr.Proxy = http.ProxyFromEnvironment
-	githubClient := github.NewClient(&http.Client{
-		Timeout:   time.Second * 5,
-		Transport: tr,
-	})
+	var issuesClient issues.Client
+	if config.IssueRepo != "" {
+		owner, repo, ok := strings.Cut(config.IssueRepo, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid issue_repo %q, want owner/repo", config.IssueRepo)
+		}
+		issuesClient = issues.NewGitHubClient(githubClient, owner, repo)
+	}
+
+	var suppressEngine *suppress.Engine
+	if config.SuppressRulesPath != "" {
+		suppressEngine, err = suppress.NewEngine(config.SuppressRulesPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed loading suppress rules")
+		}
+	}
+	if config.SuppressAdminAddr != "" {
+		if config.SuppressAdminToken == "" {
+			golog.Child("[ctrl]").Warnf("suppress admin server on %s has no token configured, anyone who can reach it can mark vulns as false positives", config.SuppressAdminAddr)
+		}
+		go func() {
+			if err := http.ListenAndServe(config.SuppressAdminAddr, suppress.AdminHandler(db, config.SuppressAdminToken)); err != nil {
+				golog.Child("[ctrl]").Errorf("suppress admin server exited, %s", err)
+			}
+		}()
+	}
+
+	var cveRepo *cvelist.Repo
+	if !config.NoCVEListEnrich {
+		cacheDir := config.CVEListCacheDir
+		if cacheDir == "" {
+			cacheDir = "cvelistV5"
+		}
+		cveRepo, err = cvelist.Open(ctx, cacheDir, "")
+		if err != nil {
+			// enrichment is optional, so a clone failure (e.g. offline) must
+			// not prevent the app from starting
+			golog.Child("[ctrl]").Warnf("failed opening cvelistV5 cache, enrichment disabled, %s", err)
+			cveRepo = nil
+		}
+	}
+
+	var nucleiIndexer *nuclei.Indexer
+	if !config.NoNucleiSearch {
+		nucleiIndexer = nuclei.NewIndexer(githubClient, db, config.NucleiCacheDir)
+	}
 
 	return &WatchVulnApp{
-		config:       config,
-		textPusher:   textPusher,
-		rawPusher:    rawPusher,
-		log:          golog.Child("[ctrl]"),
-		db:           dbClient,
-		githubClient: githubClient,
-		grabbers:     grabs,
+		config:         config,
+		textPusher:     textPusher,
+		rawPusher:      rawPusher,
+		log:            golog.Child("[ctrl]"),
+		db:             db,
+		githubClient:   githubClient,
+		issuesClient:   issuesClient,
+		suppressEngine: suppressEngine,
+		cveRepo:        cveRepo,
+		nucleiIndexer:  nucleiIndexer,
+		grabbers:       grabs,
 	}, nil
 }
 
+// newStore picks a store.Store backend based on config.StoreDriver, falling
+// back to the historical on-disk SQLite file when it is left empty.
+func newStore(ctx context.Context, config *WatchVulnAppConfig) (store.Store, error) {
+	driver := strings.ToLower(strings.TrimSpace(config.StoreDriver))
+	switch driver {
+	case "", "sqlite3":
+		dsn := config.StoreDSN
+		if dsn == "" {
+			dsn = "file:vuln_v2.sqlite3?cache=shared&_pragma=foreign_keys(1)"
+		}
+		return store.NewEntStore(ctx, store.DriverSQLite, dsn)
+	case "postgres", "mysql":
+		return store.NewEntStore(ctx, store.Driver(driver), config.StoreDSN)
+	case "redis":
+		return store.NewRedisStore(ctx, config.StoreDSN)
+	case "firestore":
+		return store.NewFirestoreStore(ctx, config.StoreDSN)
+	default:
+		return nil, fmt.Errorf("invalid store driver %q", config.StoreDriver)
+	}
+}
+
 func (w *WatchVulnApp) Run(ctx context.Context) error {
 	w.log.Infof("initialize local database..")
 	// 抓取前3页作为基准漏洞数据
@@ -100,7 +176,7 @@ func (w *WatchVulnApp) Run(ctx context.Context) error {
 	}
 	w.log.Infof("grabber finished successfully")
 
-	localCount, err := w.db.VulnInformation.Query().Count(ctx)
+	localCount, err := w.db.CountAll(ctx)
 	if err != nil {
 		return err
 	}
@@ -137,10 +213,28 @@ func (w *WatchVulnApp) Run(ctx context.Context) error {
 		time.Sleep(time.Second)
 	}()
 
+	if w.config.HTTPAddr != "" {
+		if w.config.HTTPToken == "" {
+			w.log.Warnf("http api on %s has no token configured, POST /tick and /replay are open to anyone who can reach it", w.config.HTTPAddr)
+		}
+		srv := httpapi.NewServer(w.db, httpapi.Hooks{
+			Tick:   func() error { return w.tick(ctx) },
+			Replay: func(key string) error { return w.replay(ctx, key) },
+		}, w.config.HTTPToken)
+		go func() {
+			if err := srv.ListenAndServe(w.config.HTTPAddr); err != nil {
+				w.log.Errorf("http api server exited, %s", err)
+			}
+		}()
+	}
+
+	if w.nucleiIndexer != nil {
+		go w.nucleiIndexer.Start(ctx)
+	}
+
 	ticker := time.NewTicker(w.config.Interval)
 	defer ticker.Stop()
 	for {
-		w.prs = nil
 		w.log.Infof("next checking at %s\n", time.Now().Add(w.config.Interval).Format("2006-01-02 15:04:05"))
 
 		select {
@@ -153,76 +247,184 @@ func (w *WatchVulnApp) Run(ctx context.Context) error {
 				w.log.Infof("sleeping..")
 				continue
 			}
+			if err := w.tick(ctx); err != nil {
+				w.log.Errorf("failed ticking, %s", err)
+			}
+		}
+	}
+}
 
-			vulns, err := w.collectUpdate(ctx)
+// tick runs one collectUpdate pass and pushes whatever comes out of it. It
+// is called both by the regular ticker and by the HTTP API's POST /tick.
+func (w *WatchVulnApp) tick(ctx context.Context) error {
+	w.tickMu.Lock()
+	defer w.tickMu.Unlock()
+
+	if w.cveRepo != nil {
+		if err := w.cveRepo.Refresh(ctx); err != nil {
+			w.log.Warnf("failed to refresh cvelistV5 cache, enrichment may be stale, %s", err)
+		}
+	}
+
+	tickStart := time.Now()
+	vulns, err := w.collectUpdate(ctx)
+	metrics.TickerDuration.Observe(time.Since(tickStart).Seconds())
+	if err != nil {
+		w.log.Errorf("failed to get updates, %s", err)
+	}
+	w.log.Infof("found %d new vulns in this ticking", len(vulns))
+	for _, v := range vulns {
+		// checked unconditionally: the admin endpoint that marks a key false
+		// positive (config.SuppressAdminAddr) is wired up independently of
+		// the rules file, so this can't be gated on w.suppressEngine
+		falsePositive, err := w.db.IsFalsePositive(ctx, v.UniqueKey)
+		if err != nil {
+			w.log.Errorf("failed to check false positive status for %s, %s", v.UniqueKey, err)
+		} else if falsePositive {
+			w.log.Infof("%s previously marked as false positive, skipped", v)
+			if err := w.db.MarkPushed(ctx, v.UniqueKey, nil); err != nil {
+				w.log.Errorf("failed to save pushed %s status, %s", v.UniqueKey, err)
+			}
+			continue
+		}
+
+		decision := suppress.Decision{}
+		if w.suppressEngine != nil {
+			decision = w.suppressEngine.Evaluate(ctx, v)
+			if decision.Action == suppress.ActionDowngrade {
+				w.log.Infof("%s downgraded from %s to %s by rule %s", v, v.Severity, decision.NewSeverity, decision.RuleID)
+				v.Severity = decision.NewSeverity
+			}
+		}
+
+		// ActionSuppress must mark the vuln pushed even when it would never
+		// have passed IsValuable, otherwise it keeps re-entering this loop
+		// and being re-evaluated against the rules on every tick
+		if decision.Action == suppress.ActionSuppress {
+			w.log.Infof("%s suppressed by rule %s", v, decision.RuleID)
+			if err := w.db.MarkPushed(ctx, v.UniqueKey, nil); err != nil {
+				w.log.Errorf("failed to save pushed %s status, %s", v.UniqueKey, err)
+			}
+			continue
+		}
+
+		if w.config.NoFilter || v.Creator.IsValuable(v) || decision.Action == suppress.ActionForcePush {
+			dbVuln, err := w.db.GetByKey(ctx, v.UniqueKey)
 			if err != nil {
-				w.log.Errorf("failed to get updates, %s", err)
+				w.log.Errorf("failed to query %s from db %s", v.UniqueKey, err)
+				continue
 			}
-			w.log.Infof("found %d new vulns in this ticking", len(vulns))
-			for _, v := range vulns {
-				if w.config.NoFilter || v.Creator.IsValuable(v) {
-					dbVuln, err := w.db.VulnInformation.Query().Where(vulninformation.Key(v.UniqueKey)).First(ctx)
-					if err != nil {
-						w.log.Errorf("failed to query %s from db %s", v.UniqueKey, err)
-						continue
-					}
-					if dbVuln.Pushed {
-						w.log.Infof("%s has been pushed, skipped", v)
-						continue
-					}
-					if v.CVE != "" && w.config.EnableCVEFilter {
-						// 同一个 cve 已经有其它源推送过了
-						others, err := w.db.VulnInformation.Query().
-							Where(vulninformation.And(vulninformation.Cve(v.CVE), vulninformation.Pushed(true))).All(ctx)
-						if err != nil {
-							w.log.Errorf("failed to query %s from db %s", v.UniqueKey, err)
-							continue
-						}
-						if len(others) != 0 {
-							ids := make([]string, 0, len(others))
-							for _, o := range others {
-								ids = append(ids, o.Key)
-							}
-							w.log.Infof("found new cve but other source has already pushed, others: %v", ids)
-							continue
-						}
-					}
-					_, err = dbVuln.Update().SetPushed(true).Save(ctx)
-					if err != nil {
-						w.log.Errorf("failed to save pushed %s status, %s", v.UniqueKey, err)
-						continue
+			if dbVuln == nil {
+				w.log.Errorf("%s not found in db after upsert, skipped", v.UniqueKey)
+				continue
+			}
+			if dbVuln.Pushed {
+				w.log.Infof("%s has been pushed, skipped", v)
+				continue
+			}
+			if v.CVE != "" && w.config.EnableCVEFilter {
+				// 同一个 cve 已经有其它源推送过了
+				others, err := w.db.FindByCVEPushed(ctx, v.CVE)
+				if err != nil {
+					w.log.Errorf("failed to query %s from db %s", v.UniqueKey, err)
+					continue
+				}
+				if len(others) != 0 {
+					ids := make([]string, 0, len(others))
+					for _, o := range others {
+						ids = append(ids, o.Key)
 					}
+					w.log.Infof("found new cve but other source has already pushed, others: %v", ids)
+					continue
+				}
+			}
 
-					// find cve pr in nuclei repo
-					if v.CVE != "" && !w.config.NoNucleiSearch {
-						links, err := w.findNucleiPRLink(ctx, v.CVE)
-						if err != nil {
-							w.log.Warnf("failed to get nuclei link, %s", err)
-						}
-						w.log.Infof("%s found %d prs from nuclei-templates", v.CVE, len(links))
-						if len(links) != 0 {
-							v.References = mergeUniqueString(v.References, links)
-							_, err = dbVuln.Update().SetReferences(v.References).Save(ctx)
-							if err != nil {
-								w.log.Warnf("failed to save %s references,  %s", v.UniqueKey, err)
-							}
-						}
-					}
-					w.log.Infof("Pushing %s", v)
-					err = w.textPusher.PushMarkdown(v.Title, push.RenderVulnInfo(v))
-					if err != nil {
-						w.log.Errorf("text-pusher send dingding msg error, %s", err)
-					}
-					err = w.rawPusher.PushRaw(push.NewRawVulnInfoMessage(v))
-					if err != nil {
-						w.log.Errorf("raw-pusher send dingding msg error, %s", err)
-					}
-				} else {
-					w.log.Infof("skipped %s as not valuable", v)
+			// enrich with the canonical cvelistV5 record before pushing; a
+			// miss or a stale local cache must not block the push pipeline
+			if v.CVE != "" && w.cveRepo != nil {
+				if err := w.enrichFromCVEList(ctx, v); err != nil {
+					w.log.Debugf("failed to enrich %s from cvelistV5, %s", v.CVE, err)
 				}
 			}
+
+			// attach known detection coverage from nuclei-templates
+			if v.CVE != "" && w.nucleiIndexer != nil {
+				links, err := w.nucleiIndexer.Lookup(ctx, v.CVE)
+				if err != nil {
+					w.log.Warnf("failed to get nuclei link, %s", err)
+				}
+				w.log.Infof("%s found %d nuclei-templates links", v.CVE, len(links))
+				if len(links) != 0 {
+					v.References = mergeUniqueString(v.References, links)
+				}
+			}
+			if err := w.db.MarkPushed(ctx, v.UniqueKey, v.References); err != nil {
+				w.log.Errorf("failed to save pushed %s status, %s", v.UniqueKey, err)
+				continue
+			}
+			w.log.Infof("Pushing %s", v)
+			err = w.textPusher.PushMarkdown(v.Title, push.RenderVulnInfo(v))
+			if err != nil {
+				w.log.Errorf("text-pusher send dingding msg error, %s", err)
+				metrics.PushFailures.WithLabelValues("text").Inc()
+			} else {
+				metrics.PushedMessages.WithLabelValues("text").Inc()
+			}
+			err = w.rawPusher.PushRaw(push.NewRawVulnInfoMessage(v))
+			if err != nil {
+				w.log.Errorf("raw-pusher send dingding msg error, %s", err)
+				metrics.PushFailures.WithLabelValues("raw").Inc()
+			} else {
+				metrics.PushedMessages.WithLabelValues("raw").Inc()
+			}
+			if w.issuesClient != nil {
+				if err := w.issuesClient.FileOrUpdate(ctx, v); err != nil {
+					w.log.Warnf("failed to file issue for %s, %s", v.UniqueKey, err)
+				}
+			}
+		} else {
+			w.log.Infof("skipped %s as not valuable", v)
 		}
 	}
+	return nil
+}
+
+// replay re-pushes the stored vuln identified by key through both pushers,
+// for the HTTP API's POST /replay/{key}. ctx is Run's long-lived app
+// context, so replay is canceled on shutdown the same as tick is.
+func (w *WatchVulnApp) replay(ctx context.Context, key string) error {
+	record, err := w.db.GetByKey(ctx, key)
+	if err != nil {
+		return errors.Wrap(err, "querying vuln")
+	}
+	if record == nil {
+		return fmt.Errorf("vuln %s not found", key)
+	}
+
+	v := &grab.VulnInfo{
+		UniqueKey:   record.Key,
+		Title:       record.Title,
+		Description: record.Description,
+		Severity:    grab.SeverityLevel(record.Severity),
+		CVE:         record.Cve,
+		Disclosure:  record.Disclosure,
+		Solutions:   record.Solutions,
+		References:  record.References,
+		Tags:        record.Tags,
+		From:        record.From,
+	}
+
+	if err := w.textPusher.PushMarkdown(v.Title, push.RenderVulnInfo(v)); err != nil {
+		metrics.PushFailures.WithLabelValues("text").Inc()
+		return errors.Wrap(err, "text-pusher replay")
+	}
+	metrics.PushedMessages.WithLabelValues("text").Inc()
+	if err := w.rawPusher.PushRaw(push.NewRawVulnInfoMessage(v)); err != nil {
+		metrics.PushFailures.WithLabelValues("raw").Inc()
+		return errors.Wrap(err, "raw-pusher replay")
+	}
+	metrics.PushedMessages.WithLabelValues("raw").Inc()
+	return nil
 }
 
 func (w *WatchVulnApp) Close() {
@@ -254,6 +456,7 @@ func (w *WatchVulnApp) initData(ctx context.Context, grabber grab.Grabber) error
 			if err != nil {
 				return err
 			}
+			metrics.FetchedPages.WithLabelValues(source.Name).Inc()
 			for data := range dataChan {
 				if _, err = w.createOrUpdate(ctx, source, data); err != nil {
 					return errors.Wrap(err, data.String())
@@ -322,99 +525,46 @@ func (w *WatchVulnApp) collectUpdate(ctx context.Context) ([]*grab.VulnInfo, err
 }
 
 func (w *WatchVulnApp) createOrUpdate(ctx context.Context, source *grab.Provider, data *grab.VulnInfo) (bool, error) {
-	vuln, err := w.db.VulnInformation.Query().
-		Where(vulninformation.Key(data.UniqueKey)).
-		First(ctx)
-	// not exist
-	if err != nil {
-		data.Reason = append(data.Reason, grab.ReasonNewCreated)
-		newVuln, err := w.db.VulnInformation.
-			Create().
-			SetKey(data.UniqueKey).
-			SetTitle(data.Title).
-			SetDescription(data.Description).
-			SetSeverity(string(data.Severity)).
-			SetCve(data.CVE).
-			SetDisclosure(data.Disclosure).
-			SetSolutions(data.Solutions).
-			SetReferences(data.References).
-			SetPushed(false).
-			SetTags(data.Tags).
-			SetFrom(data.From).
-			Save(ctx)
-		if err != nil {
-			return false, err
-		}
-		w.log.Debugf("vuln %d created from %s %s", newVuln.ID, newVuln.Key, source.Name)
-		return true, nil
-	}
-
-	// 如果一个漏洞之前是低危，后来改成了严重，这种可能也需要推送, 走一下高价值的判断逻辑
-	asNewVuln := false
-	if string(data.Severity) != vuln.Severity {
-		w.log.Infof("%s from %s change severity from %s to %s", data.Title, data.From, vuln.Severity, data.Severity)
-		data.Reason = append(data.Reason, fmt.Sprintf("%s: %s => %s", grab.ReasonSeverityUpdated, vuln.Severity, data.Severity))
-		asNewVuln = true
-	}
-	for _, newTag := range data.Tags {
-		found := false
-		for _, dbTag := range vuln.Tags {
-			if newTag == dbTag {
-				found = true
-				break
-			}
-		}
-		// tag 有更新
-		if !found {
-			w.log.Infof("%s from %s add new tag %s", data.Title, data.From, newTag)
-			data.Reason = append(data.Reason, fmt.Sprintf("%s: %v => %v", grab.ReasonTagUpdated, vuln.Tags, data.Tags))
-			asNewVuln = true
-			break
-		}
-	}
-
-	// update
-	newVuln, err := vuln.Update().SetKey(data.UniqueKey).
-		SetTitle(data.Title).
-		SetDescription(data.Description).
-		SetSeverity(string(data.Severity)).
-		SetCve(data.CVE).
-		SetDisclosure(data.Disclosure).
-		SetSolutions(data.Solutions).
-		SetReferences(data.References).
-		SetTags(data.Tags).
-		SetFrom(data.From).
-		Save(ctx)
+	result, err := w.db.Upsert(ctx, data)
 	if err != nil {
 		return false, err
 	}
-	w.log.Debugf("vuln %d updated from %s %s", newVuln.ID, newVuln.Key, source.Name)
-	return asNewVuln, nil
+	kind := "updated"
+	if result.Created {
+		kind = "created"
+	}
+	metrics.VulnsUpserted.WithLabelValues(source.Name, kind).Inc()
+	w.log.Debugf("vuln %s upserted from %s, asNewVuln=%v created=%v", data.UniqueKey, source.Name, result.AsNewVuln, result.Created)
+	return result.AsNewVuln, nil
 }
 
-func (w *WatchVulnApp) findNucleiPRLink(ctx context.Context, cveId string) ([]string, error) {
-	if w.prs == nil {
-		prs, _, err := w.githubClient.PullRequests.List(ctx, "projectdiscovery", "nuclei-templates", &github.PullRequestListOptions{
-			State:       "all",
-			ListOptions: github.ListOptions{Page: 1, PerPage: 100},
-		})
-		if err != nil {
-			return nil, err
-		}
-		w.prs = prs
+// enrichFromCVEList merges the canonical cvelistV5 record for v.CVE into v
+// and persists the merged fields back through the store.
+func (w *WatchVulnApp) enrichFromCVEList(ctx context.Context, v *grab.VulnInfo) error {
+	rec, err := w.cveRepo.Enrich(v.CVE)
+	if err != nil {
+		return err
 	}
 
-	var links []string
-	re, err := regexp.Compile(fmt.Sprintf(`(?)\b%s\b`, cveId))
-	if err != nil {
-		return nil, err
+	v.References = mergeUniqueString(v.References, rec.References)
+	if rec.CVSSVector != "" {
+		v.Tags = mergeUniqueString(v.Tags, []string{fmt.Sprintf("cvss:%.1f:%s", rec.CVSSBaseScore, rec.CVSSVector)})
 	}
-	for _, pr := range w.prs {
-		if re.MatchString(pr.GetTitle()) || re.MatchString(pr.GetBody()) {
-			links = append(links, pr.GetHTMLURL())
-		}
+	for _, cwe := range rec.CWEIDs {
+		v.Tags = mergeUniqueString(v.Tags, []string{cwe})
+	}
+	if rec.AffectedVendor != "" || rec.AffectedProduct != "" {
+		v.Tags = mergeUniqueString(v.Tags, []string{
+			strings.TrimSpace(fmt.Sprintf("%s/%s", rec.AffectedVendor, rec.AffectedProduct)),
+		})
 	}
-	return links, nil
+	v.Tags = mergeUniqueString(v.Tags, rec.VersionRanges)
+	if v.Description == "" {
+		v.Description = rec.Description
+	}
+
+	_, err = w.db.Upsert(ctx, v)
+	return err
 }
 
 func mergeUniqueString(s1 []string, s2 []string) []string {
@@ -430,4 +580,4 @@ func mergeUniqueString(s1 []string, s2 []string) []string {
 		res = append(res, k)
 	}
 	return res
-}
\ No newline at end of file
+}
@@ -0,0 +1,120 @@
+// Package cvelist enriches grab.VulnInfo records with the canonical fields
+// from MITRE's cvelistV5 repository (https://github.com/CVEProject/cvelistV5),
+// which the CN-centric sources (AVD/TI/OSCS/Seebug) often omit: the CVSS v3.1
+// vector/score, CWE IDs, the official English CNA description and canonical
+// reference URLs.
+package cvelist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/kataras/golog"
+	"github.com/pkg/errors"
+)
+
+// DefaultRemote is the upstream mirrored by Repo.
+const DefaultRemote = "https://github.com/CVEProject/cvelistV5.git"
+
+// Repo is a local shallow clone of cvelistV5 kept up to date by Refresh.
+type Repo struct {
+	dir    string
+	remote string
+	log    *golog.Logger
+}
+
+// Open clones remote into dir if it isn't already a checkout, then returns a
+// Repo ready to serve Enrich. dir is created if missing.
+func Open(ctx context.Context, dir, remote string) (*Repo, error) {
+	if remote == "" {
+		remote = DefaultRemote
+	}
+	r := &Repo{dir: dir, remote: remote, log: golog.Child("[cvelist]")}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); errors.Is(err, os.ErrNotExist) {
+		r.log.Infof("cloning %s into %s, this may take a while", remote, dir)
+		_, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+			URL:   remote,
+			Depth: 1,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "cloning cvelistV5")
+		}
+	}
+	return r, nil
+}
+
+// Refresh does a shallow fetch of the latest commit and fast-forwards the
+// working tree. Failures are the caller's responsibility to treat as
+// non-fatal, per the enrichment step being optional.
+func (r *Repo) Refresh(ctx context.Context) error {
+	repo, err := git.PlainOpen(r.dir)
+	if err != nil {
+		return errors.Wrap(err, "opening cvelistV5 checkout")
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return errors.Wrap(err, "opening worktree")
+	}
+	err = wt.PullContext(ctx, &git.PullOptions{
+		RemoteName: "origin",
+		Depth:      1,
+		Force:      true,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return errors.Wrap(err, "pulling cvelistV5")
+	}
+	return nil
+}
+
+// Record is the subset of a CVE JSON 5.x record we surface to grab.VulnInfo.
+type Record struct {
+	CVSSVector      string
+	CVSSBaseScore   float64
+	CWEIDs          []string
+	Description     string
+	AffectedVendor  string
+	AffectedProduct string
+	VersionRanges   []string
+	References      []string
+}
+
+// Enrich loads and parses the JSON record for cveID from the local
+// checkout. cvelistV5 shards records as
+// cves/<year>/<Nxxx>/CVE-<year>-<N>.json where Nxxx is the CVE number with
+// its last three digits replaced by "xxx", e.g. CVE-2024-12345 ->
+// cves/2024/12xxx/.
+func (r *Repo) Enrich(cveID string) (*Record, error) {
+	path, err := r.recordPath(cveID)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+
+	var doc cveJSON5
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", path)
+	}
+	return doc.toRecord(), nil
+}
+
+func (r *Repo) recordPath(cveID string) (string, error) {
+	parts := strings.SplitN(cveID, "-", 3)
+	if len(parts) != 3 || parts[0] != "CVE" {
+		return "", fmt.Errorf("invalid cve id %q", cveID)
+	}
+	year, num := parts[1], parts[2]
+	if len(num) < 4 {
+		return "", fmt.Errorf("invalid cve id %q", cveID)
+	}
+	bucket := num[:len(num)-3] + "xxx"
+	return filepath.Join(r.dir, "cves", year, bucket, cveID+".json"), nil
+}
@@ -0,0 +1,55 @@
+package cvelist
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordPath(t *testing.T) {
+	r := &Repo{dir: "cvelistV5"}
+
+	cases := []struct {
+		cve     string
+		want    string
+		wantErr bool
+	}{
+		{
+			cve:  "CVE-2024-12345",
+			want: filepath.Join("cvelistV5", "cves", "2024", "12xxx", "CVE-2024-12345.json"),
+		},
+		{
+			cve:  "CVE-2021-0001",
+			want: filepath.Join("cvelistV5", "cves", "2021", "0xxx", "CVE-2021-0001.json"),
+		},
+		{
+			cve:  "CVE-1999-9999",
+			want: filepath.Join("cvelistV5", "cves", "1999", "9xxx", "CVE-1999-9999.json"),
+		},
+		{
+			cve:     "not-a-cve",
+			wantErr: true,
+		},
+		{
+			cve:     "CVE-2024-1",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.cve, func(t *testing.T) {
+			got, err := r.recordPath(tc.cve)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("recordPath(%q) = %q, want error", tc.cve, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("recordPath(%q) returned unexpected error: %s", tc.cve, err)
+			}
+			if got != tc.want {
+				t.Errorf("recordPath(%q) = %q, want %q", tc.cve, got, tc.want)
+			}
+		})
+	}
+}
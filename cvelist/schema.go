@@ -0,0 +1,85 @@
+package cvelist
+
+// cveJSON5 is the (heavily trimmed) shape of a CVE Record Format v5 JSON
+// document, covering only what Enrich needs. See
+// https://github.com/CVEProject/cve-schema for the full schema.
+type cveJSON5 struct {
+	Containers struct {
+		CNA struct {
+			Descriptions []struct {
+				Lang  string `json:"lang"`
+				Value string `json:"value"`
+			} `json:"descriptions"`
+			Metrics []struct {
+				CvssV3_1 struct {
+					VectorString string  `json:"vectorString"`
+					BaseScore    float64 `json:"baseScore"`
+				} `json:"cvssV3_1"`
+			} `json:"metrics"`
+			ProblemTypes []struct {
+				Descriptions []struct {
+					CweID       string `json:"cweId"`
+					Description string `json:"description"`
+				} `json:"descriptions"`
+			} `json:"problemTypes"`
+			Affected []struct {
+				Vendor   string `json:"vendor"`
+				Product  string `json:"product"`
+				Versions []struct {
+					Version  string `json:"version"`
+					Status   string `json:"status"`
+					LessThan string `json:"lessThan"`
+				} `json:"versions"`
+			} `json:"affected"`
+			References []struct {
+				URL string `json:"url"`
+			} `json:"references"`
+		} `json:"cna"`
+	} `json:"containers"`
+}
+
+func (d *cveJSON5) toRecord() *Record {
+	rec := &Record{}
+
+	for _, desc := range d.Containers.CNA.Descriptions {
+		if desc.Lang == "en" || desc.Lang == "en-US" {
+			rec.Description = desc.Value
+			break
+		}
+	}
+
+	for _, m := range d.Containers.CNA.Metrics {
+		if m.CvssV3_1.VectorString != "" {
+			rec.CVSSVector = m.CvssV3_1.VectorString
+			rec.CVSSBaseScore = m.CvssV3_1.BaseScore
+			break
+		}
+	}
+
+	for _, pt := range d.Containers.CNA.ProblemTypes {
+		for _, desc := range pt.Descriptions {
+			if desc.CweID != "" {
+				rec.CWEIDs = append(rec.CWEIDs, desc.CweID)
+			}
+		}
+	}
+
+	if len(d.Containers.CNA.Affected) > 0 {
+		a := d.Containers.CNA.Affected[0]
+		rec.AffectedVendor = a.Vendor
+		rec.AffectedProduct = a.Product
+		for _, v := range a.Versions {
+			r := v.Version
+			if v.LessThan != "" {
+				r += " < " + v.LessThan
+			}
+			rec.VersionRanges = append(rec.VersionRanges, r)
+		}
+	}
+
+	for _, ref := range d.Containers.CNA.References {
+		rec.References = append(rec.References, ref.URL)
+	}
+
+	return rec
+}
@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// FalsePositive records a vuln key permanently flagged through the
+// suppression admin endpoint, so store.Store.IsFalsePositive can skip it on
+// every future reappearance regardless of severity/tag changes.
+type FalsePositive struct {
+	ent.Schema
+}
+
+func (FalsePositive) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").NotEmpty(),
+	}
+}
+
+func (FalsePositive) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("key").Unique(),
+	}
+}
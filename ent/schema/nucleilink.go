@@ -0,0 +1,27 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// NucleiLink persists the nuclei-templates URLs nuclei.Indexer found for a
+// CVE, so a restart can serve store.Store.NucleiLinks without re-scanning
+// GitHub and the template tree.
+type NucleiLink struct {
+	ent.Schema
+}
+
+func (NucleiLink) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("cve").NotEmpty(),
+		field.Strings("links").Optional(),
+	}
+}
+
+func (NucleiLink) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("cve").Unique(),
+	}
+}
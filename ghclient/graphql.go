@@ -0,0 +1,11 @@
+package ghclient
+
+import "github.com/shurcooL/githubv4"
+
+// NewGraphQL builds a *githubv4.Client for GitHub's GraphQL API (used by
+// grab.GHSACrawler) on the same rate-limited transport as New, so it draws
+// from the same budget instead of opening its own client straight off
+// GITHUB_TOKEN.
+func NewGraphQL(token string) *githubv4.Client {
+	return githubv4.NewClient(httpClient(token))
+}
@@ -0,0 +1,76 @@
+// Package ghclient builds the GitHub clients shared by every subsystem that
+// talks to the GitHub API (nuclei PR search, issue filing, the GHSA
+// GraphQL crawler, ...) so they draw from one rate limit budget instead of
+// each opening their own transport and exhausting the API quota
+// independently.
+package ghclient
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v53/github"
+	"github.com/zema1/watchvuln/metrics"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+// defaultRPS caps every subsystem sharing the client at a combined 2 req/s
+// (bursts of up to 4). Sustained for an hour that is 7200 requests, so this
+// cap alone does not keep a continuous load under GitHub's 5000 req/hour
+// authenticated limit or the much lower unauthenticated one.
+const defaultRPS = 2
+
+// rateLimitedTransport throttles outgoing requests with a token bucket
+// before handing them to the underlying transport.
+type rateLimitedTransport struct {
+	underlying http.RoundTripper
+	limiter    *rate.Limiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := t.underlying.RoundTrip(req)
+	// recorded here, not by individual callers, so the gauge reflects every
+	// GitHub API call this app makes (REST or GraphQL, issues or nuclei)
+	// instead of going blank for deployments that don't run one of them
+	if resp != nil {
+		if remaining, convErr := strconv.ParseFloat(resp.Header.Get("X-RateLimit-Remaining"), 64); convErr == nil {
+			metrics.GithubAPICallsRemaining.Set(remaining)
+		}
+	}
+	return resp, err
+}
+
+// httpClient builds the rate-limited transport shared by every GitHub API
+// caller, REST (New) or GraphQL (NewGraphQL), authenticating it with token
+// when one is given.
+func httpClient(token string) *http.Client {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.Proxy = http.ProxyFromEnvironment
+
+	var limited http.RoundTripper = &rateLimitedTransport{
+		underlying: tr,
+		limiter:    rate.NewLimiter(rate.Limit(defaultRPS), defaultRPS*2),
+	}
+	if token != "" {
+		limited = &oauth2.Transport{
+			Base:   limited,
+			Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+		}
+	}
+	return &http.Client{
+		Timeout:   time.Second * 10,
+		Transport: limited,
+	}
+}
+
+// New builds a *github.Client for the REST API whose transport is shared and
+// rate-limited. Pass an empty token to get an unauthenticated (much more
+// constrained) client.
+func New(token string) *github.Client {
+	return github.NewClient(httpClient(token))
+}
@@ -0,0 +1,247 @@
+package grab
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kataras/golog"
+	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
+)
+
+// ghsaQuery mirrors the subset of GitHub's GraphQL SecurityAdvisory schema
+// we care about, see https://docs.github.com/en/graphql/reference/objects#securityadvisory
+type ghsaQuery struct {
+	SecurityAdvisories struct {
+		Nodes []struct {
+			GhsaId      githubv4.String
+			Summary     githubv4.String
+			Description githubv4.String
+			Severity    githubv4.String
+			PublishedAt githubv4.DateTime
+			Permalink   githubv4.String
+			Identifiers []struct {
+				Type  githubv4.String
+				Value githubv4.String
+			}
+			References []struct {
+				Url githubv4.String
+			}
+			Vulnerabilities struct {
+				Nodes []struct {
+					Package struct {
+						Ecosystem githubv4.String
+						Name      githubv4.String
+					}
+					VulnerableVersionRange githubv4.String
+				}
+			} `graphql:"vulnerabilities(first: 10)"`
+		}
+		PageInfo struct {
+			HasNextPage githubv4.Boolean
+			EndCursor   githubv4.String
+		}
+	} `graphql:"securityAdvisories(first: $pageSize, after: $cursor, orderBy: {field: PUBLISHED_AT, direction: DESC})"`
+}
+
+// GHSACrawler pulls GitHub Security Advisories via the GraphQL API. Since the
+// API only supports cursor pagination but initData/collectUpdate fetch pages
+// concurrently, the crawler chains the cursor internally: each page is
+// fetched exactly once per scan, and concurrent ParsePage calls for pages
+// other than "the next one" block until their turn instead of each re-walking
+// from the start.
+type GHSACrawler struct {
+	client *githubv4.Client
+	log    *golog.Logger
+
+	mu             sync.Mutex
+	cond           *sync.Cond
+	pages          map[int][]*VulnInfo
+	pageErrs       map[int]error
+	fetchedThrough int
+	cursor         *githubv4.String
+	done           bool
+}
+
+// NewGHSACrawler builds a crawler on top of client, which should come from
+// ghclient.NewGraphQL so it shares the app's rate limit budget instead of
+// opening its own client straight off an env var.
+func NewGHSACrawler(client *githubv4.Client) *GHSACrawler {
+	c := &GHSACrawler{
+		client:   client,
+		log:      golog.Child("[ghsa]"),
+		pages:    make(map[int][]*VulnInfo),
+		pageErrs: make(map[int]error),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (g *GHSACrawler) ProviderInfo() *Provider {
+	return &Provider{
+		Name: "GHSA",
+		Link: "https://github.com/advisories",
+	}
+}
+
+// GetPageCount starts a fresh scan: GHSA's GraphQL API only supports cursor
+// pagination, so this resets the cursor chain and clears any pages cached by
+// the previous scan, then returns the fixed base page count, same as the
+// other crawlers' bounded initial scan.
+func (g *GHSACrawler) GetPageCount(ctx context.Context, pageSize int) (int, error) {
+	g.mu.Lock()
+	g.pages = make(map[int][]*VulnInfo)
+	g.pageErrs = make(map[int]error)
+	g.fetchedThrough = 0
+	g.cursor = nil
+	g.done = false
+	g.mu.Unlock()
+	return MaxPageBase, nil
+}
+
+func (g *GHSACrawler) ParsePage(ctx context.Context, page, pageSize int) (chan *VulnInfo, error) {
+	vulns, err := g.fetchPage(ctx, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(chan *VulnInfo, len(vulns))
+	go func() {
+		defer close(data)
+		for _, v := range vulns {
+			data <- v
+		}
+	}()
+	return data, nil
+}
+
+// fetchPage returns the advisories for page, fetching it (and advancing the
+// shared cursor by exactly one page) the first time it's requested. Callers
+// asking for a page before it's their turn block on g.cond until the chain
+// catches up to them. Every exit from the "fetch" half below - success,
+// GHSA API error, or g.done - advances fetchedThrough and broadcasts, since
+// any of those exits must unblock goroutines parked on a later page; an
+// error fetching page N is cached and replayed to every caller of page N
+// rather than left to hang them forever.
+func (g *GHSACrawler) fetchPage(ctx context.Context, page, pageSize int) ([]*VulnInfo, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// g.cond.Wait() has no way to observe ctx itself, so give it something
+	// to wake up to: a watcher that broadcasts the moment ctx is canceled.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			g.mu.Lock()
+			g.cond.Broadcast()
+			g.mu.Unlock()
+		case <-stop:
+		}
+	}()
+
+	for {
+		if vulns, ok := g.pages[page]; ok {
+			if err := g.pageErrs[page]; err != nil {
+				return nil, err
+			}
+			return vulns, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if g.fetchedThrough == page-1 {
+			break
+		}
+		g.cond.Wait()
+	}
+
+	if g.done {
+		g.pages[page] = nil
+		g.fetchedThrough = page
+		g.cond.Broadcast()
+		return nil, nil
+	}
+
+	var q ghsaQuery
+	vars := map[string]interface{}{
+		"pageSize": githubv4.Int(pageSize),
+		"cursor":   g.cursor,
+	}
+	if err := g.client.Query(ctx, &q, vars); err != nil {
+		wrapped := errors.Wrap(err, "query ghsa page")
+		g.pages[page] = nil
+		g.pageErrs[page] = wrapped
+		g.fetchedThrough = page
+		g.cond.Broadcast()
+		return nil, wrapped
+	}
+	if bool(q.SecurityAdvisories.PageInfo.HasNextPage) {
+		cursor := q.SecurityAdvisories.PageInfo.EndCursor
+		g.cursor = &cursor
+	} else {
+		g.done = true
+	}
+
+	vulns := make([]*VulnInfo, 0, len(q.SecurityAdvisories.Nodes))
+	for _, node := range q.SecurityAdvisories.Nodes {
+		var cve string
+		for _, id := range node.Identifiers {
+			if string(id.Type) == "CVE" {
+				cve = string(id.Value)
+			}
+		}
+
+		tags := make([]string, 0, len(node.Vulnerabilities.Nodes))
+		for _, v := range node.Vulnerabilities.Nodes {
+			tags = append(tags, strings.TrimSpace(fmt.Sprintf(
+				"%s/%s %s", v.Package.Ecosystem, v.Package.Name, v.VulnerableVersionRange)))
+		}
+
+		refs := make([]string, 0, len(node.References)+1)
+		refs = append(refs, string(node.Permalink))
+		for _, r := range node.References {
+			refs = append(refs, string(r.Url))
+		}
+
+		vulns = append(vulns, &VulnInfo{
+			UniqueKey:   string(node.GhsaId),
+			Title:       string(node.Summary),
+			Description: string(node.Description),
+			Severity:    ghsaSeverity(string(node.Severity)),
+			CVE:         cve,
+			Disclosure:  node.PublishedAt.Format("2006-01-02"),
+			References:  refs,
+			Tags:        tags,
+			From:        g.ProviderInfo().Link,
+			Creator:     g,
+		})
+	}
+
+	g.pages[page] = vulns
+	g.fetchedThrough = page
+	g.cond.Broadcast()
+	return vulns, nil
+}
+
+func (g *GHSACrawler) IsValuable(info *VulnInfo) bool {
+	return info.Severity == Critical || info.Severity == High
+}
+
+// ghsaSeverity maps GHSA's own severity enum (LOW/MODERATE/HIGH/CRITICAL)
+// into the app's shared severity scheme.
+func ghsaSeverity(s string) SeverityLevel {
+	switch strings.ToUpper(s) {
+	case "CRITICAL":
+		return Critical
+	case "HIGH":
+		return High
+	case "MODERATE":
+		return Medium
+	default:
+		return Low
+	}
+}
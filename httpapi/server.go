@@ -0,0 +1,210 @@
+// Package httpapi exposes WatchVulnApp over HTTP: a read-only view of the
+// store for inspection, manual triggers for the tick/push pipeline, and the
+// Prometheus /metrics endpoint. It only depends on store.Store and a small
+// Hooks struct so ctrl.WatchVulnApp can wire it up without an import cycle.
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kataras/golog"
+	"github.com/zema1/watchvuln/metrics"
+	"github.com/zema1/watchvuln/store"
+)
+
+// Hooks lets the HTTP API trigger actions that live in ctrl.WatchVulnApp
+// without httpapi importing ctrl (which already imports httpapi's siblings).
+type Hooks struct {
+	// Tick forces an immediate collectUpdate run outside the ticker.
+	Tick func() error
+	// Replay re-pushes the stored vuln identified by key through both
+	// pushers.
+	Replay func(key string) error
+}
+
+// Server is the HTTP server described by request #5: GET /vulns, GET
+// /vulns/{key}, POST /tick, POST /replay/{key} and /metrics.
+type Server struct {
+	db    store.Store
+	hooks Hooks
+	token string
+	log   *golog.Logger
+}
+
+// NewServer builds a Server. When token is non-empty, POST /tick and POST
+// /replay/{key} require a matching "Authorization: Bearer <token>" header,
+// since both can force pushes/re-pushes on demand; GET /vulns stays open,
+// and an empty token leaves every route open (e.g. for a deployment that
+// only binds HTTPAddr to localhost).
+func NewServer(db store.Store, hooks Hooks, token string) *Server {
+	return &Server{
+		db:    db,
+		hooks: hooks,
+		token: token,
+		log:   golog.Child("[httpapi]"),
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vulns", s.handleList)
+	mux.HandleFunc("/vulns/", s.handleGet)
+	mux.HandleFunc("/tick", s.requireToken(s.handleTick))
+	mux.HandleFunc("/replay/", s.requireToken(s.handleReplay))
+	mux.Handle("/metrics", metrics.Handler())
+	return mux
+}
+
+// requireToken wraps next with a bearer-token check when s.token is set, and
+// is a no-op otherwise.
+func (s *Server) requireToken(next http.HandlerFunc) http.HandlerFunc {
+	if s.token == "" {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !validBearerToken(r, s.token) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func validBearerToken(r *http.Request, token string) bool {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return false
+	}
+	got := strings.TrimPrefix(auth, prefix)
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+// ListenAndServe starts the server on addr. It's meant to be run in its own
+// goroutine by the caller.
+func (s *Server) ListenAndServe(addr string) error {
+	s.log.Infof("http api listening on %s", addr)
+	return http.ListenAndServe(addr, s.Handler())
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	filter := store.ListFilter{
+		Source:   q.Get("source"),
+		Severity: q.Get("severity"),
+		CVE:      q.Get("cve"),
+		Since:    q.Get("since"),
+		Until:    q.Get("until"),
+		Page:     atoiOr(q.Get("page"), 1),
+		PageSize: atoiOr(q.Get("page_size"), 20),
+	}
+	if raw := q.Get("pushed"); raw != "" {
+		v := raw == "true" || raw == "1"
+		filter.Pushed = &v
+	}
+
+	records, total, err := s.db.List(r.Context(), filter)
+	if err != nil {
+		s.log.Errorf("failed to list vulns, %s", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total": total,
+		"page":  filter.Page,
+		"items": records,
+	})
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/vulns/")
+	if key == "" {
+		http.Error(w, "missing key", http.StatusBadRequest)
+		return
+	}
+	record, err := s.db.GetByKey(r.Context(), key)
+	if err != nil {
+		s.log.Errorf("failed to get vuln %s, %s", key, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if record == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"record":   record,
+		"markdown": renderMarkdown(record),
+	})
+}
+
+func (s *Server) handleTick(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.hooks.Tick == nil {
+		http.Error(w, "tick not wired", http.StatusNotImplemented)
+		return
+	}
+	if err := s.hooks.Tick(); err != nil {
+		s.log.Errorf("forced tick failed, %s", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleReplay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/replay/")
+	if key == "" {
+		http.Error(w, "missing key", http.StatusBadRequest)
+		return
+	}
+	if s.hooks.Replay == nil {
+		http.Error(w, "replay not wired", http.StatusNotImplemented)
+		return
+	}
+	if err := s.hooks.Replay(key); err != nil {
+		s.log.Errorf("replay of %s failed, %s", key, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func renderMarkdown(v *store.VulnRecord) string {
+	return fmt.Sprintf("# %s\n\n**Severity**: %s\n**CVE**: %s\n**From**: %s\n\n%s",
+		v.Title, v.Severity, v.Cve, v.From, v.Description)
+}
+
+func atoiOr(s string, fallback int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,125 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v53/github"
+	"github.com/kataras/golog"
+	"github.com/pkg/errors"
+	"github.com/zema1/watchvuln/grab"
+	"github.com/zema1/watchvuln/push"
+)
+
+// githubClient files one issue per vuln in a single owner/repo, labeling it
+// with severity and CVE so users can filter their issue tracker the same way
+// they'd filter the chat pushes.
+type githubClient struct {
+	gh    *github.Client
+	owner string
+	repo  string
+	log   *golog.Logger
+}
+
+// NewGitHubClient returns a Client that files issues into owner/repo using
+// gh. gh is expected to already carry authentication and, ideally, come from
+// ghclient.New so it shares its rate limit budget with the rest of the app.
+func NewGitHubClient(gh *github.Client, owner, repo string) Client {
+	return &githubClient{
+		gh:    gh,
+		owner: owner,
+		repo:  repo,
+		log:   golog.Child("[issues]"),
+	}
+}
+
+func (c *githubClient) FileOrUpdate(ctx context.Context, v *grab.VulnInfo) error {
+	existing, err := c.find(ctx, v)
+	if err != nil {
+		return errors.Wrap(err, "searching for existing issue")
+	}
+
+	if existing == nil {
+		return c.create(ctx, v)
+	}
+	return c.reopenWithComment(ctx, existing, v)
+}
+
+// find looks for an already-filed issue for v, matched on its unique key
+// (embedded as a hidden marker in the issue body) and, failing that, on the
+// CVE label create attaches, so repeated runs don't double-file.
+func (c *githubClient) find(ctx context.Context, v *grab.VulnInfo) (*github.Issue, error) {
+	query := fmt.Sprintf("repo:%s/%s in:body %q", c.owner, c.repo, issueMarker(v))
+	result, _, err := c.gh.Search.Issues(ctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Issues) != 0 {
+		return result.Issues[0], nil
+	}
+	if v.CVE == "" {
+		return nil, nil
+	}
+	query = fmt.Sprintf("repo:%s/%s is:issue label:%q", c.owner, c.repo, v.CVE)
+	result, _, err = c.gh.Search.Issues(ctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Issues) == 0 {
+		return nil, nil
+	}
+	return result.Issues[0], nil
+}
+
+func (c *githubClient) create(ctx context.Context, v *grab.VulnInfo) error {
+	labels := []string{"vulnerability", "severity/" + strings.ToLower(string(v.Severity))}
+	if v.CVE != "" {
+		labels = append(labels, v.CVE)
+	}
+	_, _, err := c.gh.Issues.Create(ctx, c.owner, c.repo, &github.IssueRequest{
+		Title:  github.String(v.Title),
+		Body:   github.String(c.render(v)),
+		Labels: &labels,
+	})
+	if err != nil {
+		return errors.Wrap(err, "creating issue")
+	}
+	c.log.Infof("filed issue for %s in %s/%s", v.UniqueKey, c.owner, c.repo)
+	return nil
+}
+
+func (c *githubClient) reopenWithComment(ctx context.Context, issue *github.Issue, v *grab.VulnInfo) error {
+	if issue.GetState() == "closed" {
+		_, _, err := c.gh.Issues.Edit(ctx, c.owner, c.repo, issue.GetNumber(), &github.IssueRequest{
+			State: github.String("open"),
+		})
+		if err != nil {
+			return errors.Wrap(err, "reopening issue")
+		}
+	}
+	comment := fmt.Sprintf("Re-evaluated as valuable again:\n\n%s", strings.Join(v.Reason, "\n"))
+	_, _, err := c.gh.Issues.CreateComment(ctx, c.owner, c.repo, issue.GetNumber(), &github.IssueComment{
+		Body: github.String(comment),
+	})
+	if err != nil {
+		return errors.Wrap(err, "commenting on issue")
+	}
+	c.log.Infof("updated issue #%d for %s", issue.GetNumber(), v.UniqueKey)
+	return nil
+}
+
+func (c *githubClient) render(v *grab.VulnInfo) string {
+	var refs strings.Builder
+	for _, r := range v.References {
+		refs.WriteString(fmt.Sprintf("- %s\n", r))
+	}
+	return fmt.Sprintf("%s\n\n%s\n\n## References\n%s\n\n<!-- %s -->",
+		push.RenderVulnInfo(v), v.Description, refs.String(), issueMarker(v))
+}
+
+// issueMarker is embedded as an HTML comment in every filed issue's body so
+// find can recognize it on a later run without needing its own database.
+func issueMarker(v *grab.VulnInfo) string {
+	return fmt.Sprintf("watchvuln-key:%s", v.UniqueKey)
+}
@@ -0,0 +1,22 @@
+// Package issues files GitHub issues for newly discovered vulnerabilities,
+// as an alternative/companion to the chat-oriented push.TextPusher and
+// push.RawPusher.
+package issues
+
+import (
+	"context"
+
+	"github.com/zema1/watchvuln/grab"
+)
+
+// Client is implemented by every issue tracker WatchVulnApp can file
+// vulnerabilities to.
+type Client interface {
+	// FileOrUpdate creates an issue for v if one doesn't exist yet (keyed on
+	// v.UniqueKey, then v.CVE), or comments on and reopens the existing one
+	// otherwise. It's only ever called for vulns collectUpdate already
+	// judged push-worthy, so finding an existing issue here means v is
+	// reappearing (e.g. a closed issue whose vuln got re-flagged), which by
+	// itself warrants a comment and reopen.
+	FileOrUpdate(ctx context.Context, v *grab.VulnInfo) error
+}
@@ -0,0 +1,65 @@
+// Package metrics holds the Prometheus collectors shared across the grab,
+// push and ctrl pipelines so ctrl.WatchVulnApp can expose a single /metrics
+// endpoint for the whole service.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "watchvuln"
+
+var (
+	// FetchedPages counts pages fetched per source, labeled by grabber name.
+	FetchedPages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "fetched_pages_total",
+		Help:      "Number of grabber pages fetched, by source.",
+	}, []string{"source"})
+
+	// VulnsUpserted counts vulns created vs updated per source.
+	VulnsUpserted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "vulns_upserted_total",
+		Help:      "Number of vulns created or updated, by source and kind (created/updated).",
+	}, []string{"source", "kind"})
+
+	// PushedMessages counts successful pushes per channel (text/raw).
+	PushedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pushed_messages_total",
+		Help:      "Number of vuln messages successfully pushed, by channel.",
+	}, []string{"channel"})
+
+	// PushFailures counts push errors per channel.
+	PushFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "push_failures_total",
+		Help:      "Number of push attempts that failed, by channel.",
+	}, []string{"channel"})
+
+	// GithubAPICallsRemaining tracks the last observed X-RateLimit-Remaining
+	// header from the GitHub API.
+	GithubAPICallsRemaining = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "github_api_calls_remaining",
+		Help:      "Remaining GitHub API calls in the current rate limit window.",
+	})
+
+	// TickerDuration times how long one collectUpdate tick takes.
+	TickerDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ticker_duration_seconds",
+		Help:      "Duration of a single collectUpdate tick.",
+		Buckets:   prometheus.DefBuckets,
+	})
+)
+
+// Handler returns the http.Handler to mount at /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
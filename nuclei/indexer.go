@@ -0,0 +1,204 @@
+// Package nuclei maintains a CVE -> []URL index over
+// projectdiscovery/nuclei-templates, so ctrl.WatchVulnApp can attach existing
+// detection coverage to a vuln without burning GitHub quota on every ticker
+// fire. It combines two sources: open/closed pull requests (via paginated,
+// conditional REST calls) and the template files already merged into the
+// http/cves/ tree (via a local git clone), and persists the merged result
+// through store.Store so a restart doesn't have to re-scan either one.
+package nuclei
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v53/github"
+	"github.com/kataras/golog"
+	"github.com/pkg/errors"
+	"github.com/zema1/watchvuln/store"
+)
+
+const (
+	owner = "projectdiscovery"
+	repo  = "nuclei-templates"
+
+	// DefaultInterval is how often Start re-scans both sources.
+	DefaultInterval = time.Hour
+)
+
+var cveRe = regexp.MustCompile(`CVE-\d{4}-\d{4,7}`)
+
+// Indexer is a long-lived, periodically refreshed CVE -> []URL index over
+// nuclei-templates. It is safe for concurrent use; Lookup is the hot path and
+// only ever takes a read lock.
+type Indexer struct {
+	client *github.Client
+	db     store.Store
+	log    *golog.Logger
+
+	interval time.Duration
+	cloneDir string
+
+	mu     sync.RWMutex
+	links  map[string][]string
+	prETag string
+}
+
+// NewIndexer builds an Indexer. client should be the shared rate-limited
+// client from ghclient.New. cloneDir is where the nuclei-templates checkout
+// used for the http/cves/ scan is kept; it defaults to "nuclei-templates" in
+// the working directory.
+func NewIndexer(client *github.Client, db store.Store, cloneDir string) *Indexer {
+	if cloneDir == "" {
+		cloneDir = "nuclei-templates"
+	}
+	return &Indexer{
+		client:   client,
+		db:       db,
+		log:      golog.Child("[nuclei]"),
+		interval: DefaultInterval,
+		cloneDir: cloneDir,
+		links:    make(map[string][]string),
+	}
+}
+
+// Start runs Refresh immediately and then every interval until ctx is
+// canceled. It is meant to be run in its own goroutine.
+func (idx *Indexer) Start(ctx context.Context) {
+	if err := idx.Refresh(ctx); err != nil {
+		idx.log.Warnf("initial nuclei-templates index failed, %s", err)
+	}
+	ticker := time.NewTicker(idx.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := idx.Refresh(ctx); err != nil {
+				idx.log.Warnf("failed refreshing nuclei-templates index, %s", err)
+			}
+		}
+	}
+}
+
+// Lookup returns the known nuclei-templates URLs for cve in O(1). If the
+// in-memory index hasn't seen cve yet (e.g. right after process start, before
+// the first Refresh completes) it falls back to whatever was persisted by an
+// earlier run.
+func (idx *Indexer) Lookup(ctx context.Context, cve string) ([]string, error) {
+	idx.mu.RLock()
+	links, ok := idx.links[cve]
+	idx.mu.RUnlock()
+	if ok {
+		return links, nil
+	}
+	return idx.db.NucleiLinks(ctx, cve)
+}
+
+// Refresh re-walks both sources, swaps in the merged result atomically and
+// persists it so a future restart can skip straight to Lookup's db fallback.
+func (idx *Indexer) Refresh(ctx context.Context) error {
+	found := make(map[string][]string)
+	if err := idx.indexPullRequests(ctx, found); err != nil {
+		return errors.Wrap(err, "indexing pull requests")
+	}
+	if err := idx.indexTemplates(ctx, found); err != nil {
+		// the http/cves/ tree is a nice-to-have on top of the PR index, so a
+		// clone/parse failure (e.g. offline) must not discard the PR results
+		idx.log.Warnf("failed indexing nuclei-templates tree, %s", err)
+	}
+
+	idx.mu.Lock()
+	idx.links = found
+	idx.mu.Unlock()
+
+	for cve, links := range found {
+		if err := idx.db.SaveNucleiLinks(ctx, cve, links); err != nil {
+			idx.log.Warnf("failed persisting nuclei links for %s, %s", cve, err)
+		}
+	}
+	return nil
+}
+
+// indexPullRequests walks every open+closed PR, paginating to the end, and
+// records the CVEs mentioned in each title/body. The first page is requested
+// with If-None-Match set to the ETag from the previous full scan so a
+// no-op hourly tick (the common case) costs a single 304 instead of a full
+// re-fetch.
+func (idx *Indexer) indexPullRequests(ctx context.Context, found map[string][]string) error {
+	page := 1
+	for {
+		req, err := idx.client.NewRequest("GET", fmt.Sprintf("repos/%s/%s/pulls", owner, repo), nil)
+		if err != nil {
+			return err
+		}
+		q := req.URL.Query()
+		q.Set("state", "all")
+		q.Set("per_page", "100")
+		q.Set("page", fmt.Sprint(page))
+		req.URL.RawQuery = q.Encode()
+		if page == 1 && idx.prETag != "" {
+			req.Header.Set("If-None-Match", idx.prETag)
+		}
+
+		var prs []*github.PullRequest
+		resp, err := idx.client.Do(ctx, req, &prs)
+		if resp != nil && resp.StatusCode == http.StatusNotModified {
+			// nothing changed since the last full scan, so the PR side of
+			// the previous index is still accurate; keep it as-is
+			idx.mu.RLock()
+			for cve, links := range idx.links {
+				found[cve] = links
+			}
+			idx.mu.RUnlock()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if page == 1 {
+			idx.prETag = resp.Header.Get("ETag")
+		}
+
+		for _, pr := range prs {
+			for _, cve := range uniqueCVEs(pr.GetTitle() + "\n" + pr.GetBody()) {
+				found[cve] = appendUnique(found[cve], pr.GetHTMLURL())
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		page = resp.NextPage
+	}
+	return nil
+}
+
+func uniqueCVEs(text string) []string {
+	matches := cveRe.FindAllString(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(matches))
+	out := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if _, ok := seen[m]; ok {
+			continue
+		}
+		seen[m] = struct{}{}
+		out = append(out, m)
+	}
+	return out
+}
+
+func appendUnique(urls []string, url string) []string {
+	for _, u := range urls {
+		if u == url {
+			return urls
+		}
+	}
+	return append(urls, url)
+}
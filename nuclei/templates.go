@@ -0,0 +1,101 @@
+package nuclei
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// templatesDir is the subtree of nuclei-templates that holds CVE PoCs.
+const templatesDir = "http/cves"
+
+// remoteURL is the upstream cloned for the template-file side of the index.
+const remoteURL = "https://github.com/" + owner + "/" + repo + ".git"
+
+// templateDoc is the subset of a nuclei template's frontmatter we need: the
+// id field is a CVE in the templates this indexer cares about.
+type templateDoc struct {
+	ID string `yaml:"id"`
+}
+
+// indexTemplates clones (or updates) a local checkout of nuclei-templates
+// and records one URL per CVE-named template under templatesDir, pointing at
+// its canonical GitHub blob URL.
+func (idx *Indexer) indexTemplates(ctx context.Context, found map[string][]string) error {
+	if err := idx.syncClone(ctx); err != nil {
+		return errors.Wrap(err, "syncing nuclei-templates checkout")
+	}
+
+	root := filepath.Join(idx.cloneDir, filepath.FromSlash(templatesDir))
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".yaml") {
+			return nil
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", path)
+		}
+		var doc templateDoc
+		if err := yaml.Unmarshal(raw, &doc); err != nil {
+			idx.log.Debugf("skipping unparseable template %s, %s", path, err)
+			return nil
+		}
+		if !cveRe.MatchString(doc.ID) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(idx.cloneDir, path)
+		if err != nil {
+			return err
+		}
+		blobURL := "https://github.com/" + owner + "/" + repo + "/blob/main/" + filepath.ToSlash(rel)
+		found[doc.ID] = appendUnique(found[doc.ID], blobURL)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// syncClone clones remoteURL into idx.cloneDir if it isn't already a
+// checkout, otherwise fast-forwards it. Mirrors cvelist.Repo's shallow
+// clone/pull handling.
+func (idx *Indexer) syncClone(ctx context.Context) error {
+	if _, err := os.Stat(filepath.Join(idx.cloneDir, ".git")); errors.Is(err, os.ErrNotExist) {
+		idx.log.Infof("cloning %s into %s, this may take a while", remoteURL, idx.cloneDir)
+		_, err := git.PlainCloneContext(ctx, idx.cloneDir, false, &git.CloneOptions{
+			URL:   remoteURL,
+			Depth: 1,
+		})
+		return err
+	}
+
+	r, err := git.PlainOpen(idx.cloneDir)
+	if err != nil {
+		return err
+	}
+	wt, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+	err = wt.PullContext(ctx, &git.PullOptions{
+		RemoteName: "origin",
+		Depth:      1,
+		Force:      true,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package store
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"github.com/pkg/errors"
+	"github.com/zema1/watchvuln/grab"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// firestoreCollection holds one document per VulnRecord, keyed by its Key.
+const firestoreCollection = "watchvuln_vulns"
+
+// falsePositiveCollection holds one document per permanently suppressed key,
+// named by the key and holding it in a "key" field.
+const falsePositiveCollection = "watchvuln_falsepositives"
+
+// nucleiLinkCollection holds one document per CVE indexed by nuclei.Indexer.
+const nucleiLinkCollection = "watchvuln_nuclei_links"
+
+// firestoreStore is the other shared-store option alongside redisStore, for
+// deployments already standardized on GCP rather than running their own
+// Redis.
+type firestoreStore struct {
+	client *firestore.Client
+}
+
+// NewFirestoreStore opens a Firestore client for projectID using whatever
+// credentials are ambient in the environment (GOOGLE_APPLICATION_CREDENTIALS).
+func NewFirestoreStore(ctx context.Context, projectID string) (Store, error) {
+	client, err := firestore.NewClient(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating firestore client")
+	}
+	return &firestoreStore{client: client}, nil
+}
+
+func (s *firestoreStore) col() *firestore.CollectionRef {
+	return s.client.Collection(firestoreCollection)
+}
+
+func (s *firestoreStore) Upsert(ctx context.Context, data *grab.VulnInfo) (UpsertResult, error) {
+	existing, err := s.GetByKey(ctx, data.UniqueKey)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+
+	created := existing == nil
+	asNewVuln := created
+	if existing == nil {
+		data.Reason = append(data.Reason, grab.ReasonNewCreated)
+	} else {
+		if string(data.Severity) != existing.Severity {
+			asNewVuln = true
+		}
+		for _, newTag := range data.Tags {
+			found := false
+			for _, oldTag := range existing.Tags {
+				if newTag == oldTag {
+					found = true
+					break
+				}
+			}
+			if !found {
+				asNewVuln = true
+				break
+			}
+		}
+	}
+
+	record := &VulnRecord{
+		Key:         data.UniqueKey,
+		Title:       data.Title,
+		Description: data.Description,
+		Severity:    string(data.Severity),
+		Cve:         data.CVE,
+		Disclosure:  data.Disclosure,
+		Solutions:   data.Solutions,
+		References:  data.References,
+		Tags:        data.Tags,
+		From:        data.From,
+	}
+	if existing != nil {
+		record.Pushed = existing.Pushed
+	}
+	_, err = s.col().Doc(record.Key).Set(ctx, record)
+	if err != nil {
+		return UpsertResult{}, errors.Wrap(err, "upsert firestore doc")
+	}
+	return UpsertResult{AsNewVuln: asNewVuln, Created: created}, nil
+}
+
+func (s *firestoreStore) GetByKey(ctx context.Context, key string) (*VulnRecord, error) {
+	doc, err := s.col().Doc(key).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record VulnRecord
+	if err := doc.DataTo(&record); err != nil {
+		return nil, errors.Wrap(err, "decode firestore doc")
+	}
+	return &record, nil
+}
+
+func (s *firestoreStore) MarkPushed(ctx context.Context, key string, references []string) error {
+	updates := []firestore.Update{{Path: "Pushed", Value: true}}
+	if references != nil {
+		updates = append(updates, firestore.Update{Path: "References", Value: references})
+	}
+	_, err := s.col().Doc(key).Update(ctx, updates)
+	return err
+}
+
+func (s *firestoreStore) FindByCVEPushed(ctx context.Context, cve string) ([]*VulnRecord, error) {
+	iter := s.col().Where("Cve", "==", cve).Where("Pushed", "==", true).Documents(ctx)
+	defer iter.Stop()
+
+	var records []*VulnRecord
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var record VulnRecord
+		if err := doc.DataTo(&record); err != nil {
+			return nil, errors.Wrap(err, "decode firestore doc")
+		}
+		records = append(records, &record)
+	}
+	return records, nil
+}
+
+func (s *firestoreStore) CountAll(ctx context.Context) (int, error) {
+	docs, err := s.col().Documents(ctx).GetAll()
+	if err != nil {
+		return 0, err
+	}
+	return len(docs), nil
+}
+
+// List applies the server-side equality filters Firestore supports and
+// falls back to in-process filtering for the rest, then paginates.
+func (s *firestoreStore) List(ctx context.Context, filter ListFilter) ([]*VulnRecord, int, error) {
+	q := s.col().Query
+	if filter.Source != "" {
+		q = q.Where("From", "==", filter.Source)
+	}
+	if filter.Severity != "" {
+		q = q.Where("Severity", "==", filter.Severity)
+	}
+	if filter.CVE != "" {
+		q = q.Where("Cve", "==", filter.CVE)
+	}
+	if filter.Pushed != nil {
+		q = q.Where("Pushed", "==", *filter.Pushed)
+	}
+
+	docs, err := q.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var all []*VulnRecord
+	for _, doc := range docs {
+		var record VulnRecord
+		if err := doc.DataTo(&record); err != nil {
+			return nil, 0, errors.Wrap(err, "decode firestore doc")
+		}
+		if filter.Since != "" && record.Disclosure < filter.Since {
+			continue
+		}
+		if filter.Until != "" && record.Disclosure > filter.Until {
+			continue
+		}
+		all = append(all, &record)
+	}
+
+	total := len(all)
+	page, pageSize := filter.Page, filter.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	start := (page - 1) * pageSize
+	if start >= total {
+		return nil, total, nil
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return all[start:end], total, nil
+}
+
+func (s *firestoreStore) IsFalsePositive(ctx context.Context, key string) (bool, error) {
+	_, err := s.client.Collection(falsePositiveCollection).Doc(key).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *firestoreStore) MarkFalsePositive(ctx context.Context, key string) error {
+	_, err := s.client.Collection(falsePositiveCollection).Doc(key).Set(ctx, map[string]interface{}{"key": key})
+	return err
+}
+
+func (s *firestoreStore) NucleiLinks(ctx context.Context, cve string) ([]string, error) {
+	doc, err := s.client.Collection(nucleiLinkCollection).Doc(cve).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var payload struct {
+		Links []string `firestore:"links"`
+	}
+	if err := doc.DataTo(&payload); err != nil {
+		return nil, errors.Wrap(err, "decode firestore doc")
+	}
+	return payload.Links, nil
+}
+
+func (s *firestoreStore) SaveNucleiLinks(ctx context.Context, cve string, links []string) error {
+	_, err := s.client.Collection(nucleiLinkCollection).Doc(cve).Set(ctx, map[string]interface{}{"links": links})
+	return err
+}
+
+func (s *firestoreStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,264 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/zema1/watchvuln/grab"
+)
+
+// redisKeyPrefix namespaces every key this store touches so it can share a
+// Redis instance with other applications.
+const redisKeyPrefix = "watchvuln:vuln:"
+
+// cveIndexPrefix indexes Key -> set member under "watchvuln:cve:<cve>" so
+// FindByCVEPushed doesn't need a full scan.
+const cveIndexPrefix = "watchvuln:cve:"
+
+// falsePositiveSet holds every key permanently flagged as a false positive.
+const falsePositiveSet = "watchvuln:falsepositive"
+
+// nucleiLinkPrefix namespaces the persisted nuclei.Indexer CVE -> URLs map.
+const nucleiLinkPrefix = "watchvuln:nuclei:"
+
+// redisStore is a Store implementation backed by Redis, meant for
+// deployments that run more than one WatchVulnApp replica against a shared
+// cache instead of a local SQLite file. It trades ent's relational queries
+// for one JSON-encoded string per vuln plus a small per-CVE set index.
+type redisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore dials addr (a redis:// URL, e.g. "redis://localhost:6379/0").
+func NewRedisStore(ctx context.Context, addr string) (Store, error) {
+	opt, err := redis.ParseURL(addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid redis address")
+	}
+	rdb := redis.NewClient(opt)
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		return nil, errors.Wrap(err, "failed connecting to redis")
+	}
+	return &redisStore{rdb: rdb}, nil
+}
+
+func (s *redisStore) Upsert(ctx context.Context, data *grab.VulnInfo) (UpsertResult, error) {
+	key := redisKeyPrefix + data.UniqueKey
+	existing, err := s.GetByKey(ctx, data.UniqueKey)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+
+	created := existing == nil
+	asNewVuln := created
+	if !asNewVuln {
+		if string(data.Severity) != existing.Severity {
+			data.Reason = append(data.Reason, fmt.Sprintf("%s: %s => %s", grab.ReasonSeverityUpdated, existing.Severity, data.Severity))
+			asNewVuln = true
+		}
+		for _, newTag := range data.Tags {
+			found := false
+			for _, oldTag := range existing.Tags {
+				if newTag == oldTag {
+					found = true
+					break
+				}
+			}
+			if !found {
+				data.Reason = append(data.Reason, fmt.Sprintf("%s: %v => %v", grab.ReasonTagUpdated, existing.Tags, data.Tags))
+				asNewVuln = true
+				break
+			}
+		}
+	} else {
+		data.Reason = append(data.Reason, grab.ReasonNewCreated)
+	}
+
+	record := &VulnRecord{
+		Key:         data.UniqueKey,
+		Title:       data.Title,
+		Description: data.Description,
+		Severity:    string(data.Severity),
+		Cve:         data.CVE,
+		Disclosure:  data.Disclosure,
+		Solutions:   data.Solutions,
+		References:  data.References,
+		Tags:        data.Tags,
+		From:        data.From,
+	}
+	if existing != nil {
+		record.Pushed = existing.Pushed
+	}
+	if err := s.save(ctx, key, record); err != nil {
+		return UpsertResult{}, err
+	}
+	if record.Cve != "" {
+		if err := s.rdb.SAdd(ctx, cveIndexPrefix+record.Cve, record.Key).Err(); err != nil {
+			return UpsertResult{}, errors.Wrap(err, "index cve")
+		}
+	}
+	return UpsertResult{AsNewVuln: asNewVuln, Created: created}, nil
+}
+
+func (s *redisStore) GetByKey(ctx context.Context, key string) (*VulnRecord, error) {
+	raw, err := s.rdb.Get(ctx, redisKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record VulnRecord
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, errors.Wrap(err, "decode vuln record")
+	}
+	return &record, nil
+}
+
+func (s *redisStore) MarkPushed(ctx context.Context, key string, references []string) error {
+	record, err := s.GetByKey(ctx, key)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return fmt.Errorf("vuln %s not found", key)
+	}
+	record.Pushed = true
+	if references != nil {
+		record.References = references
+	}
+	return s.save(ctx, redisKeyPrefix+key, record)
+}
+
+func (s *redisStore) FindByCVEPushed(ctx context.Context, cve string) ([]*VulnRecord, error) {
+	keys, err := s.rdb.SMembers(ctx, cveIndexPrefix+cve).Result()
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*VulnRecord, 0, len(keys))
+	for _, key := range keys {
+		record, err := s.GetByKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil && record.Pushed {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (s *redisStore) CountAll(ctx context.Context) (int, error) {
+	var count int
+	iter := s.rdb.Scan(ctx, 0, redisKeyPrefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		count++
+	}
+	return count, iter.Err()
+}
+
+// List scans every record and applies filter in-process. Redis has no
+// secondary indexes for most of these fields, so this is O(n) in the number
+// of stored vulns; fine for the admin/debug use case it serves.
+func (s *redisStore) List(ctx context.Context, filter ListFilter) ([]*VulnRecord, int, error) {
+	var all []*VulnRecord
+	iter := s.rdb.Scan(ctx, 0, redisKeyPrefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		record, err := s.GetByKey(ctx, iter.Val()[len(redisKeyPrefix):])
+		if err != nil {
+			return nil, 0, err
+		}
+		if record != nil && matches(record, filter) {
+			all = append(all, record)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	total := len(all)
+	page, pageSize := filter.Page, filter.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	start := (page - 1) * pageSize
+	if start >= total {
+		return nil, total, nil
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return all[start:end], total, nil
+}
+
+func matches(r *VulnRecord, filter ListFilter) bool {
+	if filter.Source != "" && r.From != filter.Source {
+		return false
+	}
+	if filter.Severity != "" && r.Severity != filter.Severity {
+		return false
+	}
+	if filter.CVE != "" && r.Cve != filter.CVE {
+		return false
+	}
+	if filter.Pushed != nil && r.Pushed != *filter.Pushed {
+		return false
+	}
+	if filter.Since != "" && r.Disclosure < filter.Since {
+		return false
+	}
+	if filter.Until != "" && r.Disclosure > filter.Until {
+		return false
+	}
+	return true
+}
+
+func (s *redisStore) IsFalsePositive(ctx context.Context, key string) (bool, error) {
+	return s.rdb.SIsMember(ctx, falsePositiveSet, key).Result()
+}
+
+func (s *redisStore) MarkFalsePositive(ctx context.Context, key string) error {
+	return s.rdb.SAdd(ctx, falsePositiveSet, key).Err()
+}
+
+func (s *redisStore) NucleiLinks(ctx context.Context, cve string) ([]string, error) {
+	raw, err := s.rdb.Get(ctx, nucleiLinkPrefix+cve).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var links []string
+	if err := json.Unmarshal(raw, &links); err != nil {
+		return nil, errors.Wrap(err, "decode nuclei links")
+	}
+	return links, nil
+}
+
+func (s *redisStore) SaveNucleiLinks(ctx context.Context, cve string, links []string) error {
+	raw, err := json.Marshal(links)
+	if err != nil {
+		return errors.Wrap(err, "encode nuclei links")
+	}
+	return s.rdb.Set(ctx, nucleiLinkPrefix+cve, raw, 0).Err()
+}
+
+func (s *redisStore) Close() error {
+	return s.rdb.Close()
+}
+
+func (s *redisStore) save(ctx context.Context, key string, record *VulnRecord) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "encode vuln record")
+	}
+	return s.rdb.Set(ctx, key, raw, 0).Err()
+}
@@ -0,0 +1,99 @@
+// Package store abstracts the persistence layer used by ctrl.WatchVulnApp so
+// it is not hard-wired to a single on-disk SQLite file. All backends operate
+// on the plain VulnRecord struct rather than ent's generated types so that
+// non-ent backends (e.g. a Redis-backed store) can implement Store without
+// depending on the ent schema.
+package store
+
+import (
+	"context"
+
+	"github.com/zema1/watchvuln/grab"
+)
+
+// VulnRecord is the persisted view of a grab.VulnInfo, plus the bookkeeping
+// fields (Pushed) that only the store needs to know about.
+type VulnRecord struct {
+	Key         string
+	Title       string
+	Description string
+	Severity    string
+	Cve         string
+	Disclosure  string
+	Solutions   string
+	References  []string
+	Tags        []string
+	From        string
+	Pushed      bool
+}
+
+// ListFilter narrows down the result of List. Zero-valued fields are not
+// applied. Since/Until compare against Disclosure using its "2006-01-02"
+// layout, so they're plain string bounds rather than parsed times.
+type ListFilter struct {
+	Source   string
+	Severity string
+	CVE      string
+	Pushed   *bool
+	Since    string
+	Until    string
+
+	Page     int
+	PageSize int
+}
+
+// UpsertResult reports what Upsert did to a record.
+type UpsertResult struct {
+	// AsNewVuln reports whether the vuln should be treated as new for push
+	// purposes: a brand new row, or an existing one whose severity or tags
+	// changed enough to warrant re-evaluation.
+	AsNewVuln bool
+	// Created reports whether Upsert inserted a brand-new row as opposed to
+	// updating an existing one in place. Unlike AsNewVuln, it only reflects
+	// row existence, not whether the change is push-worthy, so metrics can
+	// label a genuine create separately from a severity/tag-changed update.
+	Created bool
+}
+
+// Store is implemented by every persistence backend WatchVulnApp can use.
+type Store interface {
+	// Upsert creates the record if Key doesn't exist yet, or updates it in
+	// place otherwise.
+	Upsert(ctx context.Context, data *grab.VulnInfo) (UpsertResult, error)
+
+	// GetByKey returns the stored record for key, or nil if it doesn't exist.
+	GetByKey(ctx context.Context, key string) (*VulnRecord, error)
+
+	// MarkPushed flags key as pushed and persists the (possibly enriched)
+	// references for it.
+	MarkPushed(ctx context.Context, key string, references []string) error
+
+	// FindByCVEPushed returns every already-pushed record sharing cve, used
+	// by the cross-source CVE dedup filter.
+	FindByCVEPushed(ctx context.Context, cve string) ([]*VulnRecord, error)
+
+	// CountAll returns the total number of stored records.
+	CountAll(ctx context.Context) (int, error)
+
+	// List returns a page of records matching filter, plus the total count
+	// of records matching it (ignoring pagination), for the HTTP API.
+	List(ctx context.Context, filter ListFilter) ([]*VulnRecord, int, error)
+
+	// IsFalsePositive reports whether key was permanently flagged as a false
+	// positive through the suppression admin endpoint.
+	IsFalsePositive(ctx context.Context, key string) (bool, error)
+
+	// MarkFalsePositive permanently flags key so it is skipped on every
+	// future reappearance, even if its severity or tags later change.
+	MarkFalsePositive(ctx context.Context, key string) error
+
+	// NucleiLinks returns the nuclei-templates PR/template URLs previously
+	// indexed for cve, or nil if none are known.
+	NucleiLinks(ctx context.Context, cve string) ([]string, error)
+
+	// SaveNucleiLinks persists the nuclei-templates URLs found for cve so
+	// nuclei.Indexer doesn't need to re-scan GitHub after a restart.
+	SaveNucleiLinks(ctx context.Context, cve string, links []string) error
+
+	Close() error
+}
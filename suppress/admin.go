@@ -0,0 +1,56 @@
+package suppress
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/kataras/golog"
+	"github.com/zema1/watchvuln/store"
+)
+
+// AdminHandler returns the small HTTP handler used to permanently flag a
+// vuln as a false positive: POST /suppress/{key}. Mount it on an existing
+// *http.ServeMux or serve it standalone via http.ListenAndServe, as ctrl does
+// on SuppressAdminAddr. When token is non-empty, the
+// request must carry a matching "Authorization: Bearer <token>" header,
+// since anyone who can reach this endpoint can permanently suppress any
+// vuln; an empty token leaves it open (e.g. for a deployment that only
+// binds SuppressAdminAddr to localhost).
+func AdminHandler(db store.Store, token string) http.Handler {
+	log := golog.Child("[suppress]")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/suppress/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if token != "" && !validBearerToken(r, token) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		key := strings.TrimPrefix(r.URL.Path, "/suppress/")
+		if key == "" {
+			http.Error(w, "missing key", http.StatusBadRequest)
+			return
+		}
+		if err := db.MarkFalsePositive(r.Context(), key); err != nil {
+			log.Errorf("failed to mark %s as false positive, %s", key, err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Infof("%s permanently flagged as false positive", key)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func validBearerToken(r *http.Request, token string) bool {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return false
+	}
+	got := strings.TrimPrefix(auth, prefix)
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
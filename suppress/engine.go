@@ -0,0 +1,210 @@
+package suppress
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/kataras/golog"
+	"github.com/pkg/errors"
+	"github.com/zema1/watchvuln/grab"
+	"gopkg.in/yaml.v3"
+)
+
+// Decision is the outcome of evaluating a vuln against the loaded rule set.
+type Decision struct {
+	Action      Action
+	RuleID      string
+	NewSeverity grab.SeverityLevel
+}
+
+// severityRank orders the app's severity scheme from least to most severe so
+// MinSeverity thresholds can be compared.
+var severityRank = map[grab.SeverityLevel]int{
+	grab.Low:      0,
+	grab.Medium:   1,
+	grab.High:     2,
+	grab.Critical: 3,
+}
+
+// Engine evaluates vulns against a hot-reloaded rules file.
+type Engine struct {
+	path string
+	log  *golog.Logger
+
+	mu    sync.RWMutex
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	titleRe *regexp.Regexp
+}
+
+// NewEngine loads path once and starts watching it for changes. path may not
+// exist yet; in that case the engine starts with an empty rule set and picks
+// the rules up as soon as the file is created.
+func NewEngine(path string) (*Engine, error) {
+	e := &Engine{
+		path: path,
+		log:  golog.Child("[suppress]"),
+	}
+	if err := e.reload(); err != nil {
+		return nil, err
+	}
+	if err := e.watch(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *Engine) reload() error {
+	raw, err := os.ReadFile(e.path)
+	if errors.Is(err, os.ErrNotExist) {
+		e.mu.Lock()
+		e.rules = nil
+		e.mu.Unlock()
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "reading suppress rules file")
+	}
+
+	var set RuleSet
+	if err := yaml.Unmarshal(raw, &set); err != nil {
+		return errors.Wrap(err, "parsing suppress rules file")
+	}
+
+	compiled := make([]compiledRule, 0, len(set.Rules))
+	for _, r := range set.Rules {
+		cr := compiledRule{Rule: r}
+		if r.TitleRegex != "" {
+			re, err := regexp.Compile(r.TitleRegex)
+			if err != nil {
+				return errors.Wrapf(err, "rule %s has invalid title_regex", r.ID)
+			}
+			cr.titleRe = re
+		}
+		compiled = append(compiled, cr)
+	}
+
+	e.mu.Lock()
+	e.rules = compiled
+	e.mu.Unlock()
+	e.log.Infof("loaded %d suppress rules from %s", len(compiled), e.path)
+	return nil
+}
+
+func (e *Engine) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "creating fsnotify watcher")
+	}
+	dir := filepath.Dir(e.path)
+	if err := watcher.Add(dir); err != nil {
+		return errors.Wrapf(err, "watching %s", dir)
+	}
+	go func() {
+		for event := range watcher.Events {
+			if filepath.Clean(event.Name) != filepath.Clean(e.path) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := e.reload(); err != nil {
+				e.log.Errorf("failed to reload suppress rules, %s", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// Evaluate runs every rule against v in order and returns the first match.
+// The zero Decision (Action == "") means no rule matched, i.e. fall
+// through to the normal IsValuable/push pipeline untouched.
+func (e *Engine) Evaluate(_ context.Context, v *grab.VulnInfo) Decision {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, r := range e.rules {
+		if !r.matches(v) {
+			continue
+		}
+		d := Decision{Action: r.Action, RuleID: r.ID}
+		if r.Action == ActionDowngrade {
+			d.NewSeverity = ruleSeverity(r.DowngradeTo)
+		}
+		return d
+	}
+	return Decision{}
+}
+
+func (r compiledRule) matches(v *grab.VulnInfo) bool {
+	if r.CVEGlob != "" {
+		ok, err := filepath.Match(r.CVEGlob, v.CVE)
+		if err != nil || !ok {
+			return false
+		}
+	}
+	if r.Source != "" && !strings.EqualFold(r.Source, v.From) {
+		return false
+	}
+	if r.titleRe != nil && !r.titleRe.MatchString(v.Title) {
+		return false
+	}
+	if len(r.Tags) > 0 && !anyTagMatches(r.Tags, v.Tags) {
+		return false
+	}
+	if r.MinSeverity != "" {
+		want, ok := severityRank[ruleSeverity(r.MinSeverity)]
+		got, gotOk := severityRank[v.Severity]
+		if !ok || !gotOk || got < want {
+			return false
+		}
+	}
+	if len(r.ProductContains) > 0 && !anySubstringIn(r.ProductContains, v.Title+" "+v.Description) {
+		return false
+	}
+	return true
+}
+
+// ruleSeverity resolves a severity written in a rules file, accepting the
+// English names low/medium/high/critical as well as the app's own values.
+func ruleSeverity(s string) grab.SeverityLevel {
+	switch strings.ToLower(s) {
+	case "low":
+		return grab.Low
+	case "medium":
+		return grab.Medium
+	case "high":
+		return grab.High
+	case "critical":
+		return grab.Critical
+	}
+	return grab.SeverityLevel(s)
+}
+
+func anyTagMatches(want, have []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if strings.EqualFold(w, h) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func anySubstringIn(substrs []string, text string) bool {
+	for _, s := range substrs {
+		if strings.Contains(text, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package suppress
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/zema1/watchvuln/grab"
+)
+
+func TestCompiledRuleMatches(t *testing.T) {
+	cases := []struct {
+		name string
+		rule Rule
+		vuln *grab.VulnInfo
+		want bool
+	}{
+		{
+			name: "cve glob matches",
+			rule: Rule{CVEGlob: "CVE-2024-*"},
+			vuln: &grab.VulnInfo{CVE: "CVE-2024-12345"},
+			want: true,
+		},
+		{
+			name: "cve glob mismatch",
+			rule: Rule{CVEGlob: "CVE-2023-*"},
+			vuln: &grab.VulnInfo{CVE: "CVE-2024-12345"},
+			want: false,
+		},
+		{
+			name: "source match is case-insensitive",
+			rule: Rule{Source: "avd"},
+			vuln: &grab.VulnInfo{From: "AVD"},
+			want: true,
+		},
+		{
+			name: "source mismatch",
+			rule: Rule{Source: "avd"},
+			vuln: &grab.VulnInfo{From: "TI"},
+			want: false,
+		},
+		{
+			name: "title regex matches",
+			rule: Rule{TitleRegex: `(?i)wordpress`},
+			vuln: &grab.VulnInfo{Title: "WordPress plugin RCE"},
+			want: true,
+		},
+		{
+			name: "tag matches if any listed tag is present",
+			rule: Rule{Tags: []string{"wordpress"}},
+			vuln: &grab.VulnInfo{Tags: []string{"cms", "WordPress"}},
+			want: true,
+		},
+		{
+			name: "tag mismatch",
+			rule: Rule{Tags: []string{"wordpress"}},
+			vuln: &grab.VulnInfo{Tags: []string{"cms"}},
+			want: false,
+		},
+		{
+			name: "min severity satisfied",
+			rule: Rule{MinSeverity: "high"},
+			vuln: &grab.VulnInfo{Severity: grab.Critical},
+			want: true,
+		},
+		{
+			name: "min severity not satisfied",
+			rule: Rule{MinSeverity: "high"},
+			vuln: &grab.VulnInfo{Severity: grab.Medium},
+			want: false,
+		},
+		{
+			name: "product contains substring match over title+description",
+			rule: Rule{ProductContains: []string{"Jenkins"}},
+			vuln: &grab.VulnInfo{Title: "Jenkins RCE", Description: "affects plugin"},
+			want: true,
+		},
+		{
+			name: "all non-empty fields must match (AND semantics)",
+			rule: Rule{Source: "avd", MinSeverity: "high"},
+			vuln: &grab.VulnInfo{From: "AVD", Severity: grab.Medium},
+			want: false,
+		},
+		{
+			name: "empty rule matches everything",
+			rule: Rule{},
+			vuln: &grab.VulnInfo{From: "AVD", Severity: grab.Low},
+			want: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := compiledRule{Rule: tc.rule}
+			if tc.rule.TitleRegex != "" {
+				cr.titleRe = regexp.MustCompile(tc.rule.TitleRegex)
+			}
+			if got := cr.matches(tc.vuln); got != tc.want {
+				t.Errorf("matches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEngineEvaluate(t *testing.T) {
+	e := &Engine{
+		rules: []compiledRule{
+			{Rule: Rule{ID: "suppress-ti", Source: "ti", Action: ActionSuppress}},
+			{Rule: Rule{ID: "downgrade-jenkins", ProductContains: []string{"Jenkins"}, Action: ActionDowngrade, DowngradeTo: "low"}},
+		},
+	}
+
+	t.Run("first matching rule wins", func(t *testing.T) {
+		// matches both rules; the earlier one must win
+		d := e.Evaluate(context.Background(), &grab.VulnInfo{From: "TI", Title: "Jenkins plugin XSS"})
+		if d.Action != ActionSuppress || d.RuleID != "suppress-ti" {
+			t.Fatalf("Evaluate() = %+v", d)
+		}
+	})
+
+	t.Run("downgrade decision carries NewSeverity", func(t *testing.T) {
+		d := e.Evaluate(context.Background(), &grab.VulnInfo{From: "AVD", Title: "Jenkins plugin XSS"})
+		if d.Action != ActionDowngrade || d.NewSeverity != grab.Low {
+			t.Fatalf("Evaluate() = %+v", d)
+		}
+	})
+
+	t.Run("no match falls through to zero Decision", func(t *testing.T) {
+		d := e.Evaluate(context.Background(), &grab.VulnInfo{From: "OSCS"})
+		if d.Action != "" {
+			t.Fatalf("Evaluate() = %+v, want zero Decision", d)
+		}
+	})
+}
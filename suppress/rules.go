@@ -0,0 +1,38 @@
+package suppress
+
+// RuleSet is the on-disk (YAML or JSON) shape of the suppression rules file.
+type RuleSet struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Action is what a matching Rule does to a vuln.
+type Action string
+
+const (
+	// ActionSuppress drops the vuln silently, still marking it pushed so it
+	// never resurfaces.
+	ActionSuppress Action = "suppress"
+	// ActionDowngrade lowers the vuln's severity to DowngradeTo before the
+	// normal IsValuable/push pipeline runs.
+	ActionDowngrade Action = "downgrade"
+	// ActionForcePush always pushes the vuln, bypassing IsValuable and any
+	// later rule of lower priority.
+	ActionForcePush Action = "force_push"
+)
+
+// Rule is one suppression/override rule. All non-empty fields must match
+// (AND semantics) for the rule to apply; Tags matches if the vuln has any of
+// the listed tags.
+type Rule struct {
+	ID string `yaml:"id" json:"id"`
+
+	CVEGlob         string   `yaml:"cve_glob" json:"cve_glob"`
+	Source          string   `yaml:"source" json:"source"`
+	TitleRegex      string   `yaml:"title_regex" json:"title_regex"`
+	Tags            []string `yaml:"tags" json:"tags"`
+	MinSeverity     string   `yaml:"min_severity" json:"min_severity"`
+	ProductContains []string `yaml:"product_contains" json:"product_contains"`
+
+	Action      Action `yaml:"action" json:"action"`
+	DowngradeTo string `yaml:"downgrade_to" json:"downgrade_to"`
+}